@@ -0,0 +1,27 @@
+package telebot
+
+import "fmt"
+
+// ResponseParameters carries extra data Telegram attaches to certain
+// failed API calls, most commonly how long to wait before retrying.
+type ResponseParameters struct {
+	MigrateToChatID int64 `json:"migrate_to_chat_id"`
+	RetryAfter      int   `json:"retry_after"`
+}
+
+// wrapAPIError turns a failed Telegram API response into an error,
+// producing a *FloodError instead of a plain error whenever Telegram
+// reports a 429 with a retry_after hint, so that callers such as
+// LongPoller.Poll can special-case rate-limiting with errors.As.
+//
+// It isn't called anywhere yet: Bot.Raw, which decodes "ok": false
+// responses, lives in bot.go outside this file set. Wiring Bot.Raw to
+// call this on a 429 is what's needed for LongPoller.Poll's
+// errors.As(err, &flood) check to ever actually fire.
+func wrapAPIError(code int, description string, params *ResponseParameters) error {
+	err := fmt.Errorf("telegram: %s (%d)", description, code)
+	if code == 429 && params != nil && params.RetryAfter > 0 {
+		return &FloodError{err: err, RetryAfter: params.RetryAfter}
+	}
+	return err
+}
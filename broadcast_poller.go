@@ -0,0 +1,128 @@
+package telebot
+
+import "sync"
+
+// BroadcastPoller wraps another Poller and fans every update it produces
+// out to any number of subscribers, in addition to forwarding it along
+// the main pipeline like a regular Poller would. Unlike MiddlewarePoller,
+// which supports a single filter and blocks on dest <- upd, subscribers
+// each get their own filter and buffer, and a slow subscriber only drops
+// its own updates instead of stalling everyone else. The main pipeline
+// itself is still fed with a blocking send on dest, so a slow reader of
+// dest holds up delivery to the subscribers as well.
+//
+// This makes it possible to run independent handler groups — e.g. an
+// admin audit stream alongside the main bot loop — off a single
+// getUpdates cursor.
+type BroadcastPoller struct {
+	Poller Poller
+
+	// OnDrop, if set, is called whenever a subscriber's buffer is full
+	// and an update has to be dropped for it instead of blocking the
+	// dispatch loop.
+	OnDrop func(upd Update)
+
+	mu   sync.Mutex
+	subs map[*broadcastSub]struct{}
+}
+
+type broadcastSub struct {
+	dest   chan Update
+	filter func(*Update) bool
+}
+
+// NewBroadcastPoller constructs a new broadcast poller around original.
+func NewBroadcastPoller(original Poller) *BroadcastPoller {
+	return &BroadcastPoller{
+		Poller: original,
+		subs:   make(map[*broadcastSub]struct{}),
+	}
+}
+
+// Subscribe registers a new subscriber that receives a copy of every
+// update for which filter returns true, buffered up to buf updates deep.
+// It returns the subscriber's channel and an unsubscribe func that must
+// be called once the subscriber is done listening.
+//
+// A nil filter matches every update.
+func (p *BroadcastPoller) Subscribe(filter func(*Update) bool, buf int) (<-chan Update, func()) {
+	if buf < 1 {
+		buf = 1
+	}
+	if filter == nil {
+		filter = func(*Update) bool { return true }
+	}
+
+	sub := &broadcastSub{
+		dest:   make(chan Update, buf),
+		filter: filter,
+	}
+
+	p.mu.Lock()
+	if p.subs == nil {
+		p.subs = make(map[*broadcastSub]struct{})
+	}
+	p.subs[sub] = struct{}{}
+	p.mu.Unlock()
+
+	unsubscribe := func() {
+		p.mu.Lock()
+		delete(p.subs, sub)
+		p.mu.Unlock()
+		close(sub.dest)
+	}
+
+	return sub.dest, unsubscribe
+}
+
+// Poll runs the wrapped Poller and forwards its updates to dest, while
+// also fanning a copy out to every matching subscriber.
+func (p *BroadcastPoller) Poll(b *Bot, dest chan Update, stop chan struct{}) {
+	middle := make(chan Update, 1)
+	stopPoller := make(chan struct{})
+	stopConfirm := make(chan struct{})
+
+	go func() {
+		p.Poller.Poll(b, middle, stopPoller)
+		close(stopConfirm)
+	}()
+
+	for {
+		select {
+		case <-stop:
+			close(stopPoller)
+			<-stopConfirm
+			return
+		case upd := <-middle:
+			dest <- upd
+			p.broadcast(upd)
+		}
+	}
+}
+
+func (p *BroadcastPoller) broadcast(upd Update) {
+	dropped := 0
+
+	p.mu.Lock()
+	for sub := range p.subs {
+		if !sub.filter(&upd) {
+			continue
+		}
+
+		select {
+		case sub.dest <- upd:
+		default:
+			dropped++
+		}
+	}
+	p.mu.Unlock()
+
+	// OnDrop runs outside the lock so a callback that calls back into
+	// Subscribe/unsubscribe (e.g. to track a metric or drop the
+	// subscriber) doesn't deadlock against it.
+	if p.OnDrop != nil {
+		for i := 0; i < dropped; i++ {
+			p.OnDrop(upd)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package telebot
+
+import "testing"
+
+func TestBroadcastPollerSubscribeNilMap(t *testing.T) {
+	p := &BroadcastPoller{}
+
+	ch, unsubscribe := p.Subscribe(nil, 1)
+	defer unsubscribe()
+
+	p.broadcast(Update{ID: 1})
+
+	select {
+	case upd := <-ch:
+		if upd.ID != 1 {
+			t.Fatalf("got update %d, want 1", upd.ID)
+		}
+	default:
+		t.Fatal("expected the nil-filter subscriber to receive the update")
+	}
+}
+
+func TestBroadcastPollerFilter(t *testing.T) {
+	p := NewBroadcastPoller(nil)
+
+	ch, unsubscribe := p.Subscribe(func(u *Update) bool { return u.ID == 2 }, 1)
+	defer unsubscribe()
+
+	p.broadcast(Update{ID: 1})
+	select {
+	case <-ch:
+		t.Fatal("subscriber should not have received an update it filters out")
+	default:
+	}
+
+	p.broadcast(Update{ID: 2})
+	select {
+	case upd := <-ch:
+		if upd.ID != 2 {
+			t.Fatalf("got update %d, want 2", upd.ID)
+		}
+	default:
+		t.Fatal("expected the subscriber to receive the matching update")
+	}
+}
+
+func TestBroadcastPollerDropsWhenFull(t *testing.T) {
+	p := NewBroadcastPoller(nil)
+
+	ch, unsubscribe := p.Subscribe(nil, 1)
+	defer unsubscribe()
+
+	var dropped []Update
+	p.OnDrop = func(upd Update) {
+		dropped = append(dropped, upd)
+	}
+
+	p.broadcast(Update{ID: 1})
+	p.broadcast(Update{ID: 2}) // buffer is full, should be dropped
+
+	if len(dropped) != 1 || dropped[0].ID != 2 {
+		t.Fatalf("OnDrop calls = %v, want a single call for update 2", dropped)
+	}
+
+	if upd := <-ch; upd.ID != 1 {
+		t.Fatalf("got update %d, want 1", upd.ID)
+	}
+}
+
+func TestBroadcastPollerOnDropCanUnsubscribe(t *testing.T) {
+	p := NewBroadcastPoller(nil)
+
+	var unsubscribe func()
+	_, unsub := p.Subscribe(nil, 1)
+	unsubscribe = unsub
+
+	p.OnDrop = func(Update) {
+		// Realistic "metric/callback" pattern: react to a drop by
+		// tearing the subscriber down. Must not deadlock against
+		// broadcast's own locking.
+		unsubscribe()
+	}
+
+	p.broadcast(Update{ID: 1})
+	p.broadcast(Update{ID: 2}) // buffer is full: dropped, which triggers OnDrop
+}
+
+func TestBroadcastPollerUnsubscribe(t *testing.T) {
+	p := NewBroadcastPoller(nil)
+
+	ch, unsubscribe := p.Subscribe(nil, 1)
+	unsubscribe()
+
+	p.broadcast(Update{ID: 1})
+
+	if _, ok := <-ch; ok {
+		t.Fatal("expected the channel to be closed after unsubscribe")
+	}
+}
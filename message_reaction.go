@@ -1,5 +1,23 @@
 package telebot
 
+// Reaction endpoints, for use with Bot.Handle.
+const (
+	OnReaction      = "\areaction"
+	OnReactionCount = "\areaction_count"
+)
+
+// ReactionUpdates are the update types needed to receive MessageReaction
+// and MessageReactionCountUpdated payloads. Telegram won't push them
+// unless requested explicitly, so unlike the rest of DefaultAllowedUpdates
+// they're opt-in — add them yourself if your bot needs them, e.g.:
+//
+//	poller.AllowedUpdates = telebot.DefaultAllowedUpdates().
+//		Add(telebot.ReactionUpdates...).String()
+var ReactionUpdates = []string{
+	"message_reaction",
+	"message_reaction_count",
+}
+
 type ReactionType struct {
 	Type          string `json:"type"`
 	Emoji         string `json:"emoji"`
@@ -16,8 +34,69 @@ type MessageReaction struct {
 	NewReaction []ReactionType `json:"new_reaction"`
 }
 
+// ReactionCount is the number of times a particular reaction was added
+// to a message.
+type ReactionCount struct {
+	Type       ReactionType `json:"type"`
+	TotalCount int          `json:"total_count"`
+}
+
+// MessageReactionCountUpdated represents anonymous reaction changes on
+// a message with a non-anonymous audience, e.g. a channel post.
+type MessageReactionCountUpdated struct {
+	Chat      *Chat           `json:"chat"`
+	MessageID int             `json:"message_id"`
+	Date      int             `json:"date"`
+	Reactions []ReactionCount `json:"reactions"`
+}
+
 func New(emoji string) ReactionType {
 	return ReactionType{
 		Emoji: emoji,
 	}
 }
+
+// React changes the reactions set on an already-sent message.
+// Passing an empty slice of reactions removes them.
+func (b *Bot) React(to Editable, reactions []ReactionType, big bool) error {
+	msgID, chatID := to.MessageSig()
+
+	params := map[string]interface{}{
+		"chat_id":    chatID,
+		"message_id": msgID,
+		"reaction":   reactions,
+		"is_big":     big,
+	}
+
+	_, err := b.Raw("setMessageReaction", params)
+	return err
+}
+
+// routeReaction dispatches a reaction update to its registered handler,
+// the same way ProcessUpdate's other per-update-type branches do for
+// messages, callbacks, etc.
+//
+// NOT WIRED YET: nothing calls routeReaction, and Update (update.go)
+// doesn't carry the two fields it switches on. Both live outside this
+// file set, in bot.go/update.go, so OnReaction and OnReactionCount stay
+// unreachable until:
+//
+//  1. Update gains:
+//     MessageReaction      *MessageReaction             `json:"message_reaction,omitempty"`
+//     MessageReactionCount *MessageReactionCountUpdated `json:"message_reaction_count,omitempty"`
+//  2. ProcessUpdate calls routeReaction(u) alongside its other
+//     per-update-type branches.
+//
+// Returns true if the update was a reaction and has been routed.
+func (b *Bot) routeReaction(u Update) bool {
+	switch {
+	case u.MessageReaction != nil:
+		b.handle(OnReaction, b.NewContext(u))
+		return true
+	case u.MessageReactionCount != nil:
+		b.handle(OnReactionCount, b.NewContext(u))
+		return true
+	default:
+		return false
+	}
+}
@@ -3,10 +3,18 @@ package telebot
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
 	"sync/atomic"
 	"time"
 )
 
+const (
+	longPollerDefaultErrorTimeout    = 5 * time.Second
+	longPollerDefaultMaxErrorTimeout = 5 * time.Minute
+)
+
 // Poller is a provider of Updates.
 //
 // All pollers must implement Poll(), which accepts bot
@@ -28,6 +36,21 @@ type LongPoller struct {
 	Timeout      time.Duration
 	LastUpdateID int
 
+	// ErrorTimeout is how long Poll waits before retrying getUpdates
+	// after an error, plus up to 20% random jitter. It doubles on every
+	// consecutive failure, up to MaxErrorTimeout, and resets as soon as
+	// a request succeeds again. A *FloodError replaces the wait with its
+	// RetryAfter seconds, without jitter.
+	//
+	// Default: 5s
+	ErrorTimeout time.Duration
+
+	// MaxErrorTimeout caps the exponential backoff applied after
+	// repeated getUpdates failures.
+	//
+	// Default: 5m
+	MaxErrorTimeout time.Duration
+
 	// AllowedUpdates contains the update types
 	// you want your bot to receive.
 	//
@@ -77,8 +100,36 @@ func (u AllowedUpdates) String() string {
 	return string(b)
 }
 
+// FloodError indicates that Telegram declined the request because the
+// bot is being rate-limited, and specifies how long to wait before
+// trying again. wrapAPIError constructs one from the
+// response_parameters.retry_after Telegram sends back on HTTP 429s;
+// Bot.Raw (bot.go) needs to call it on failed responses for
+// b.getUpdates to actually surface a *FloodError here.
+type FloodError struct {
+	err        error
+	RetryAfter int
+}
+
+func (e FloodError) Error() string {
+	return fmt.Sprintf("telegram: %s (retry_after %d)", e.err, e.RetryAfter)
+}
+
+func (e FloodError) Unwrap() error {
+	return e.err
+}
+
 // Poll does long polling.
 func (p *LongPoller) Poll(b *Bot, dest chan Update, stop chan struct{}) {
+	if p.ErrorTimeout == 0 {
+		p.ErrorTimeout = longPollerDefaultErrorTimeout
+	}
+	if p.MaxErrorTimeout == 0 {
+		p.MaxErrorTimeout = longPollerDefaultMaxErrorTimeout
+	}
+
+	timeout := p.ErrorTimeout
+
 	for {
 		select {
 		case <-stop:
@@ -89,9 +140,30 @@ func (p *LongPoller) Poll(b *Bot, dest chan Update, stop chan struct{}) {
 		updates, err := b.getUpdates(p.LastUpdateID+1, p.Limit, p.Timeout, p.AllowedUpdates)
 		if err != nil {
 			b.debug(err)
+
+			wait := withJitter(timeout)
+			var flood *FloodError
+			if errors.As(err, &flood) && flood.RetryAfter > 0 {
+				// Telegram gave us an exact deadline: honor it as-is,
+				// no jitter, instead of the generic backoff estimate.
+				wait = time.Duration(flood.RetryAfter) * time.Second
+			}
+
+			select {
+			case <-stop:
+				return
+			case <-time.After(wait):
+			}
+
+			timeout *= 2
+			if timeout > p.MaxErrorTimeout {
+				timeout = p.MaxErrorTimeout
+			}
 			continue
 		}
 
+		timeout = p.ErrorTimeout
+
 		for _, update := range updates {
 			p.LastUpdateID = update.ID
 			if atomic.LoadInt64(&b.shouldWrireNextUpdate) == 1 {
@@ -103,6 +175,13 @@ func (p *LongPoller) Poll(b *Bot, dest chan Update, stop chan struct{}) {
 	}
 }
 
+// withJitter adds up to 20% of random jitter to d, so that many bots
+// backing off at once don't all hammer api.telegram.org in lockstep.
+func withJitter(d time.Duration) time.Duration {
+	jitter := time.Duration(rand.Int63n(int64(d)/5 + 1))
+	return d + jitter
+}
+
 // MiddlewarePoller is a special kind of poller that acts
 // like a filter for updates. It could be used for spam
 // handling, banning or whatever.
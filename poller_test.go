@@ -0,0 +1,26 @@
+package telebot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithJitter(t *testing.T) {
+	d := 10 * time.Second
+
+	for i := 0; i < 100; i++ {
+		got := withJitter(d)
+		if got < d {
+			t.Fatalf("withJitter(%v) = %v, want >= %v", d, got, d)
+		}
+		if max := d + d/5; got > max {
+			t.Fatalf("withJitter(%v) = %v, want <= %v", d, got, max)
+		}
+	}
+}
+
+func TestWithJitterZero(t *testing.T) {
+	if got := withJitter(0); got != 0 {
+		t.Fatalf("withJitter(0) = %v, want 0", got)
+	}
+}
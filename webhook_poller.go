@@ -0,0 +1,207 @@
+package telebot
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// telegramIPRanges are the subnets Telegram sends webhook requests from,
+// as documented at https://core.telegram.org/bots/webhooks#the-short-version.
+var telegramIPRanges = []string{
+	"149.154.160.0/20",
+	"91.108.4.0/22",
+}
+
+// WebhookPoller is a Poller that receives Updates by registering an
+// HTTPS webhook with Telegram instead of long polling. It can be used
+// as a drop-in replacement for LongPoller wherever a Bot is started
+// behind an ingress or load balancer that can terminate TLS and route
+// traffic to it.
+type WebhookPoller struct {
+	// Listen is the local address the HTTP server binds to, e.g. ":8443".
+	Listen string
+
+	// URL is the public HTTPS address Telegram should push updates to.
+	// Endpoint, if set, is appended to URL as the path Telegram will
+	// POST to (a leading slash is added automatically if missing); it
+	// also doubles as a shared secret in the URL itself, since Telegram
+	// never guarantees SecretToken support on old clients.
+	URL      string
+	Endpoint string
+
+	// CertFile and KeyFile configure the TLS certificate the server
+	// listens with. Leave both empty when TLS is terminated upstream
+	// (e.g. by an ingress) and the poller only needs to listen on
+	// plain HTTP.
+	CertFile string
+	KeyFile  string
+
+	// SecretToken, if set, is sent to Telegram when registering the
+	// webhook and is required to match the X-Telegram-Bot-Api-Secret-Token
+	// header on every incoming request.
+	SecretToken string
+
+	// AllowedIPs restricts incoming requests to the given CIDR ranges.
+	// Defaults to Telegram's published webhook subnets; set to an empty
+	// slice to disable the check entirely (e.g. behind a proxy that
+	// already filters on IP).
+	AllowedIPs []string
+
+	// AllowedUpdates contains the update types you want your bot to
+	// receive. See AllowedUpdates for details.
+	AllowedUpdates string
+
+	// DropPendingUpdates instructs Telegram to discard any updates
+	// queued while the webhook was unreachable.
+	DropPendingUpdates bool
+
+	// MaxConnections is the maximum number of simultaneous HTTPS
+	// connections Telegram will open to deliver updates. Telegram
+	// defaults to 40 when this is left at zero.
+	MaxConnections int
+
+	server *http.Server
+}
+
+// Poll registers the webhook with Telegram and starts serving updates
+// until stop is closed.
+func (p *WebhookPoller) Poll(b *Bot, dest chan Update, stop chan struct{}) {
+	if err := p.registerWebhook(b); err != nil {
+		b.debug(err)
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(p.path(), p.handler(b, dest))
+
+	p.server = &http.Server{
+		Addr:    p.Listen,
+		Handler: mux,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		if p.CertFile != "" || p.KeyFile != "" {
+			errs <- p.server.ListenAndServeTLS(p.CertFile, p.KeyFile)
+		} else {
+			errs <- p.server.ListenAndServe()
+		}
+	}()
+
+	select {
+	case <-stop:
+	case err := <-errs:
+		if err != nil && err != http.ErrServerClosed {
+			b.debug(err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p.server.Shutdown(ctx)
+}
+
+// path is the HTTP path the server listens on. Endpoint is normalized
+// to always start with a leading slash, since http.ServeMux patterns
+// and incoming request paths both require one, regardless of how the
+// caller wrote it (e.g. "secret-path" and "/secret-path" behave the same).
+func (p *WebhookPoller) path() string {
+	if p.Endpoint == "" {
+		return "/"
+	}
+	return path.Join("/", p.Endpoint)
+}
+
+func (p *WebhookPoller) handler(b *Bot, dest chan Update) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !p.allowedRemote(r) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		if p.SecretToken != "" && r.Header.Get("X-Telegram-Bot-Api-Secret-Token") != p.SecretToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var update Update
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			b.debug(err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+
+		if atomic.LoadInt64(&b.shouldWrireNextUpdate) == 1 {
+			b.nextUpdate <- update
+		} else {
+			dest <- update
+		}
+	}
+}
+
+func (p *WebhookPoller) allowedRemote(r *http.Request) bool {
+	ranges := p.AllowedIPs
+	if ranges == nil {
+		ranges = telegramIPRanges
+	}
+	if len(ranges) == 0 {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, cidr := range ranges {
+		_, subnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if subnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookURL is the public address registered with Telegram: URL with
+// the listened path appended, so the two never drift apart.
+func (p *WebhookPoller) webhookURL() string {
+	return strings.TrimRight(p.URL, "/") + p.path()
+}
+
+func (p *WebhookPoller) registerWebhook(b *Bot) error {
+	params := map[string]interface{}{
+		"url":                  p.webhookURL(),
+		"drop_pending_updates": p.DropPendingUpdates,
+	}
+	if p.SecretToken != "" {
+		params["secret_token"] = p.SecretToken
+	}
+	if p.MaxConnections > 0 {
+		params["max_connections"] = p.MaxConnections
+	}
+	if p.AllowedUpdates != "" {
+		params["allowed_updates"] = p.AllowedUpdates
+	}
+
+	_, err := b.Raw("setWebhook", params)
+	return err
+}
@@ -0,0 +1,63 @@
+package telebot
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookPollerPath(t *testing.T) {
+	cases := []struct {
+		endpoint string
+		want     string
+	}{
+		{"", "/"},
+		{"/hook", "/hook"},
+		{"hook", "/hook"},
+		{"/nested/hook", "/nested/hook"},
+	}
+
+	for _, c := range cases {
+		p := &WebhookPoller{Endpoint: c.endpoint}
+		if got := p.path(); got != c.want {
+			t.Errorf("path() with Endpoint %q = %q, want %q", c.endpoint, got, c.want)
+		}
+	}
+}
+
+func TestWebhookPollerWebhookURL(t *testing.T) {
+	cases := []struct {
+		url, endpoint, want string
+	}{
+		{"https://example.com", "", "https://example.com/"},
+		{"https://example.com/", "", "https://example.com/"},
+		{"https://example.com", "secret-path", "https://example.com/secret-path"},
+		{"https://example.com/", "/secret-path", "https://example.com/secret-path"},
+	}
+
+	for _, c := range cases {
+		p := &WebhookPoller{URL: c.url, Endpoint: c.endpoint}
+		if got := p.webhookURL(); got != c.want {
+			t.Errorf("webhookURL() with URL %q Endpoint %q = %q, want %q", c.url, c.endpoint, got, c.want)
+		}
+	}
+}
+
+func TestWebhookPollerAllowedRemote(t *testing.T) {
+	p := &WebhookPoller{}
+
+	r := httptest.NewRequest("POST", "/", nil)
+	r.RemoteAddr = "149.154.167.1:443"
+	if !p.allowedRemote(r) {
+		t.Error("expected an address inside Telegram's default ranges to be allowed")
+	}
+
+	r.RemoteAddr = "8.8.8.8:443"
+	if p.allowedRemote(r) {
+		t.Error("expected an address outside Telegram's default ranges to be rejected")
+	}
+
+	p.AllowedIPs = []string{}
+	if !p.allowedRemote(r) {
+		t.Error("expected an empty AllowedIPs to disable the check entirely")
+	}
+}